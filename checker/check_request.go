@@ -0,0 +1,30 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checker defines the raw and evaluated result types that raw checks
+// produce and evaluation checks score, independent of any single forge.
+package checker
+
+import (
+	"context"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// CheckRequest holds the state a raw check needs to inspect a repo: the
+// context to run forge calls under, and the RepoClient to run them against.
+type CheckRequest struct {
+	Ctx        context.Context
+	RepoClient clients.RepoClient
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+// MaxResultScore and MinResultScore bound the score an evaluation check can
+// report.
+const (
+	MaxResultScore = 10
+	MinResultScore = 0
+)
+
+// CheckResult is the evaluated, scored result of a single check.
+type CheckResult struct {
+	Name   string
+	Score  int
+	Reason string
+	Error  error
+}
+
+// CreateResultWithScore builds a CheckResult with an explicit score, clamped
+// to [MinResultScore, MaxResultScore].
+func CreateResultWithScore(name, reason string, score int) CheckResult {
+	switch {
+	case score > MaxResultScore:
+		score = MaxResultScore
+	case score < MinResultScore:
+		score = MinResultScore
+	}
+	return CheckResult{Name: name, Score: score, Reason: reason}
+}
+
+// CreateMaxScoreResult builds a CheckResult at MaxResultScore.
+func CreateMaxScoreResult(name, reason string) CheckResult {
+	return CreateResultWithScore(name, reason, MaxResultScore)
+}
+
+// CreateMinScoreResult builds a CheckResult at MinResultScore.
+func CreateMinScoreResult(name, reason string) CheckResult {
+	return CreateResultWithScore(name, reason, MinResultScore)
+}
+
+// CreateRuntimeErrorResult builds a CheckResult reporting that the check
+// itself failed to run, as opposed to running and scoring the repo low.
+func CreateRuntimeErrorResult(name string, err error) CheckResult {
+	return CheckResult{Name: name, Score: -1, Reason: "check failed to run", Error: err}
+}
@@ -0,0 +1,31 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+// LogMessage is a single finding an evaluation check surfaces through a
+// DetailLogger, e.g. for the CLI's verbose output or the JSON report.
+type LogMessage struct {
+	Path      string
+	Type      FileType
+	Offset    uint
+	EndOffset uint
+	Snippet   string
+}
+
+// DetailLogger collects the findings an evaluation check surfaces while
+// scoring a raw result.
+type DetailLogger interface {
+	Info(msg *LogMessage)
+}
@@ -0,0 +1,113 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checker
+
+// FileType is the kind of thing a File points at.
+type FileType int
+
+const (
+	// FileTypeText is a plain file in the repo.
+	FileTypeText FileType = iota
+	// FileTypeURL is a file located outside the repo, e.g. in an org-level
+	// health-file repo.
+	FileTypeURL
+	// FileTypeSource is a CI/build configuration file, e.g. a GitHub Actions
+	// workflow or a GitLab CI pipeline definition.
+	FileTypeSource
+)
+
+// OffsetDefault is used for a File whose match isn't tied to a specific
+// line/byte offset.
+const OffsetDefault = 1
+
+// File locates a single finding: which file, what kind it is, and where in
+// it the finding was made.
+type File struct {
+	Path      string
+	Type      FileType
+	Offset    uint
+	EndOffset uint
+	Snippet   string
+}
+
+// SecurityPolicyInformationType classifies a piece of disclosure/contact
+// information found in a security policy.
+type SecurityPolicyInformationType int
+
+const (
+	SecurityPolicyInformationTypeLink SecurityPolicyInformationType = iota
+	SecurityPolicyInformationTypeEmail
+	SecurityPolicyInformationTypeText
+)
+
+// SecurityPolicyValueType is where and what a SecurityPolicyInformation hit
+// was, within its file.
+type SecurityPolicyValueType struct {
+	Match      string
+	LineNumber uint
+	Offset     uint
+}
+
+// SecurityPolicyInformation is a single disclosure/vulnerability/contact hit
+// found in a security policy's content.
+type SecurityPolicyInformation struct {
+	InformationType  SecurityPolicyInformationType
+	InformationValue SecurityPolicyValueType
+}
+
+// SecurityPolicyFile is a single security policy found in a repo (or its
+// org-level health-file repo), along with the disclosure information found
+// in it.
+type SecurityPolicyFile struct {
+	File        File
+	Information []SecurityPolicyInformation
+	// Root is the directory this policy governs: "." for a repo-wide
+	// policy, or a subproject directory for a monorepo's per-project
+	// policy.
+	Root string
+}
+
+// SecurityPolicyData is the raw result of the Security-Policy check: every
+// security policy found, repo-local ones taking precedence over the
+// org-level fallback.
+type SecurityPolicyData struct {
+	PolicyFiles []SecurityPolicyFile
+}
+
+// DangerousWorkflowType classifies the kind of dangerous pattern found in a
+// CI workflow/pipeline definition.
+type DangerousWorkflowType int
+
+const (
+	// DangerousWorkflowUntrustedCheckout is a job that checks out an
+	// untrusted ref (e.g. a fork/merge-request branch) and then runs a step
+	// with an elevated or long-lived token against it.
+	DangerousWorkflowUntrustedCheckout DangerousWorkflowType = iota
+	// DangerousWorkflowScriptInjection is a job that interpolates
+	// attacker-controlled input directly into a shell step.
+	DangerousWorkflowScriptInjection
+)
+
+// DangerousWorkflow is a single dangerous pattern found in a CI
+// workflow/pipeline file.
+type DangerousWorkflow struct {
+	Type DangerousWorkflowType
+	File File
+}
+
+// DangerousWorkflowData is the raw result of the Dangerous-Workflow check.
+type DangerousWorkflowData struct {
+	Workflows []DangerousWorkflow
+}
@@ -0,0 +1,114 @@
+// Copyright 2020 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evaluation
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+const (
+	minimumSecurityPolicyContentLength = 100
+	considerLinkSecurityContent        = 1
+	considerEmailSecurityContent       = 1
+	considerTextSecurityContent        = 1
+)
+
+// SecurityPolicy applies the score policy for the Security-Policy check.
+//
+// A repo may carry more than one SECURITY.md (one per subproject in a
+// monorepo, plus an optional org-level fallback). Each one is scored
+// independently on content and linked/contact information, then the best
+// score wins: the aggregate result should reflect whether *a* usable policy
+// exists and is discoverable, not be dragged down by a thin per-subproject
+// stub sitting next to a solid top-level policy.
+func SecurityPolicy(name string, dl checker.DetailLogger,
+	r *checker.SecurityPolicyData,
+) checker.CheckResult {
+	if r == nil {
+		e := sce.WithMessage(sce.ErrScorecardInternal, "empty raw data")
+		return checker.CreateRuntimeErrorResult(name, e)
+	}
+
+	if len(r.PolicyFiles) == 0 {
+		return checker.CreateMinScoreResult(name, "security policy file not detected")
+	}
+
+	var best checker.CheckResult
+	bestScore := -1
+	for i := range r.PolicyFiles {
+		policy := &r.PolicyFiles[i]
+		score, reason := scoreSecurityPolicyFile(policy)
+		for _, info := range policy.Information {
+			dl.Info(&checker.LogMessage{
+				Path:      policy.File.Path,
+				Type:      policy.File.Type,
+				Offset:    info.InformationValue.Offset,
+				EndOffset: info.InformationValue.Offset,
+				Snippet:   info.InformationValue.Match,
+			})
+		}
+		if score > bestScore {
+			bestScore = score
+			best = checker.CreateResultWithScore(name, reason, score)
+		}
+	}
+	return best
+}
+
+// scoreSecurityPolicyFile scores a single security policy match on content
+// length and the disclosure information (links, emails, free text) found in
+// it, returning the score and the human-readable reason for it.
+func scoreSecurityPolicyFile(policy *checker.SecurityPolicyFile) (int, string) {
+	var numInformation int
+	for _, info := range policy.Information {
+		switch info.InformationType {
+		case checker.SecurityPolicyInformationTypeLink:
+			numInformation += considerLinkSecurityContent
+		case checker.SecurityPolicyInformationTypeEmail:
+			numInformation += considerEmailSecurityContent
+		case checker.SecurityPolicyInformationTypeText:
+			numInformation += considerTextSecurityContent
+		}
+	}
+
+	component := componentLabel(policy.Root)
+
+	switch {
+	case policy.File.EndOffset >= minimumSecurityPolicyContentLength && numInformation > 0:
+		return checker.MaxResultScore,
+			fmt.Sprintf("security policy %q found for component %q with %d hit(s) of disclosure, vulnerability, and/or contact info",
+				policy.File.Path, component, numInformation)
+	case policy.File.EndOffset >= minimumSecurityPolicyContentLength:
+		return checker.MaxResultScore - 3,
+			fmt.Sprintf("security policy %q found for component %q but no disclosure, vulnerability, or contact info found",
+				policy.File.Path, component)
+	default:
+		return checker.MaxResultScore - 6,
+			fmt.Sprintf("security policy %q found for component %q but with too little content", policy.File.Path, component)
+	}
+}
+
+// componentLabel renders a policy's Root for the evaluation reason string,
+// so findings in a monorepo with a policy per subproject can be attributed
+// to the subproject they govern rather than the repo as a whole.
+func componentLabel(root string) string {
+	if root == "" || root == "." {
+		return "repository root"
+	}
+	return root
+}
@@ -0,0 +1,34 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+
+	"github.com/ossf/scorecard/v4/checker"
+)
+
+// DangerousWorkflow checks for dangerous patterns in a repo's CI
+// configuration. This snapshot only carries the forge-independent
+// CIConfig heuristics (untrusted checkout, script injection); appending the
+// GitHub Actions workflow-file-specific heuristics is a separate,
+// pre-existing part of this check not touched by this series.
+func DangerousWorkflow(c *checker.CheckRequest) (checker.DangerousWorkflowData, error) {
+	found, err := DangerousWorkflowsFromCIConfig(c.RepoClient)
+	if err != nil {
+		return checker.DangerousWorkflowData{}, fmt.Errorf("DangerousWorkflowsFromCIConfig: %w", err)
+	}
+	return checker.DangerousWorkflowData{Workflows: found}, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// untrusted checkout: a job that checks out a fork/PR ref and then runs a
+// privileged or token-bearing step against it.
+var reUntrustedCheckoutRef = regexp.MustCompile(
+	`(?i)(CI_MERGE_REQUEST_SOURCE_BRANCH_SHA|CI_MERGE_REQUEST_SOURCE_PROJECT|pull_request)`)
+
+// script injection: untrusted, attacker-controlled input interpolated
+// directly into a shell step.
+var reScriptInjectionVar = regexp.MustCompile(
+	`(?i)\$\{?(CI_MERGE_REQUEST_TITLE|CI_MERGE_REQUEST_DESCRIPTION|CI_COMMIT_MESSAGE|CI_COMMIT_REF_NAME)\}?`)
+
+// DangerousWorkflowsFromCIConfig runs the same untrusted-checkout and
+// script-injection heuristics used for .github/workflows/*.yml against a
+// forge-independent clients.CIConfig (e.g. GitLab's .gitlab-ci.yml), so
+// Dangerous-Workflow and Token-Permissions stay host-agnostic. It fetches
+// the config itself via RepoClient.GetCIConfig, so DangerousWorkflow (in
+// dangerous_workflow.go) reports these findings as its result;
+// RepoClient implementations that don't have a
+// CI config to parse (e.g. githubrepo) return (nil, nil) and this is a
+// no-op.
+func DangerousWorkflowsFromCIConfig(rc clients.RepoClient) ([]checker.DangerousWorkflow, error) {
+	cfg, err := rc.GetCIConfig()
+	if err != nil {
+		return nil, fmt.Errorf("GetCIConfig: %w", err)
+	}
+	return dangerousWorkflowsFromCIConfig(cfg), nil
+}
+
+// dangerousWorkflowsFromCIConfig evaluates the heuristics against an
+// already-parsed CIConfig; split out from DangerousWorkflowsFromCIConfig so
+// it can be unit-tested without a RepoClient.
+func dangerousWorkflowsFromCIConfig(cfg *clients.CIConfig) []checker.DangerousWorkflow {
+	if cfg == nil {
+		return nil
+	}
+
+	var found []checker.DangerousWorkflow
+	for _, job := range cfg.Jobs {
+		if jobChecksOutUntrustedRef(job) && jobRunsWithElevatedToken(job) {
+			found = append(found, checker.DangerousWorkflow{
+				Type: checker.DangerousWorkflowUntrustedCheckout,
+				File: checker.File{
+					Path:    cfg.Path,
+					Type:    checker.FileTypeSource,
+					Snippet: fmt.Sprintf("job %q", job.Name),
+					Offset:  checker.OffsetDefault,
+				},
+			})
+		}
+		if snippet, ok := jobHasScriptInjection(job); ok {
+			found = append(found, checker.DangerousWorkflow{
+				Type: checker.DangerousWorkflowScriptInjection,
+				File: checker.File{
+					Path:    cfg.Path,
+					Type:    checker.FileTypeSource,
+					Snippet: snippet,
+					Offset:  checker.OffsetDefault,
+				},
+			})
+		}
+	}
+	return found
+}
+
+func jobChecksOutUntrustedRef(job clients.CIJob) bool {
+	for _, line := range append(job.BeforeScript, job.Script...) {
+		if reUntrustedCheckoutRef.MatchString(line) {
+			return true
+		}
+	}
+	return false
+}
+
+func jobRunsWithElevatedToken(job clients.CIJob) bool {
+	return job.UsesJobToken || job.UsesIDTokens
+}
+
+func jobHasScriptInjection(job clients.CIJob) (string, bool) {
+	for _, line := range job.Script {
+		if reScriptInjectionVar.MatchString(line) {
+			return strings.TrimSpace(line), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,87 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package raw
+
+import (
+	"testing"
+
+	"github.com/ossf/scorecard/v4/checker"
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+func Test_dangerousWorkflowsFromCIConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  *clients.CIConfig
+		want []checker.DangerousWorkflowType
+	}{
+		{
+			name: "nil config",
+			cfg:  nil,
+			want: nil,
+		},
+		{
+			name: "untrusted checkout with job token is flagged",
+			cfg: &clients.CIConfig{
+				Path: ".gitlab-ci.yml",
+				Jobs: []clients.CIJob{{
+					Name:         "deploy",
+					BeforeScript: []string{"git fetch origin $CI_MERGE_REQUEST_SOURCE_BRANCH_SHA"},
+					Script:       []string{"CI_JOB_TOKEN=$CI_JOB_TOKEN ./deploy.sh"},
+					UsesJobToken: true,
+				}},
+			},
+			want: []checker.DangerousWorkflowType{checker.DangerousWorkflowUntrustedCheckout},
+		},
+		{
+			name: "untrusted checkout without an elevated token is not flagged",
+			cfg: &clients.CIConfig{
+				Jobs: []clients.CIJob{{
+					Name:         "build",
+					BeforeScript: []string{"git fetch origin $CI_MERGE_REQUEST_SOURCE_BRANCH_SHA"},
+				}},
+			},
+			want: nil,
+		},
+		{
+			name: "script injection is flagged",
+			cfg: &clients.CIConfig{
+				Jobs: []clients.CIJob{{
+					Name:   "comment",
+					Script: []string{`echo "${CI_MERGE_REQUEST_TITLE}"`},
+				}},
+			},
+			want: []checker.DangerousWorkflowType{checker.DangerousWorkflowScriptInjection},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			found := dangerousWorkflowsFromCIConfig(tt.cfg)
+			if len(found) != len(tt.want) {
+				t.Fatalf("expected %d findings, got %d: %+v", len(tt.want), len(found), found)
+			}
+			for i, w := range tt.want {
+				if found[i].Type != w {
+					t.Errorf("finding %d: expected type %v, got %v", i, w, found[i].Type)
+				}
+			}
+		})
+	}
+}
@@ -25,91 +25,83 @@ import (
 	"github.com/ossf/scorecard/v4/checker"
 	"github.com/ossf/scorecard/v4/checks/fileparser"
 	"github.com/ossf/scorecard/v4/clients"
-	"github.com/ossf/scorecard/v4/clients/githubrepo"
 	sce "github.com/ossf/scorecard/v4/errors"
-	"github.com/ossf/scorecard/v4/log"
 )
 
+// securityPolicyFilesWithURI collects every security policy found, rather
+// than stopping at the first match, so monorepos with a policy per
+// subproject are all credited.
 type securityPolicyFilesWithURI struct {
-	info []checker.SecurityPolicyInformation
-	uri  string
-	file checker.File
+	uri      string
+	policies []checker.SecurityPolicyFile
 }
 
 // SecurityPolicy checks for presence of security policy.
 func SecurityPolicy(c *checker.CheckRequest) (checker.SecurityPolicyData, error) {
 	data := securityPolicyFilesWithURI{
-		uri:  "",
-		info: make([]checker.SecurityPolicyInformation, 0),
-		file: checker.File{
-			Path:      "",
-			Snippet:   "",
-			Offset:    0,
-			EndOffset: 0,
-			Type:      checker.FileTypeNone,
-		},
+		uri: "",
 	}
 	err := fileparser.OnAllFilesDo(c.RepoClient, isSecurityPolicyFile, &data)
 	if err != nil {
 		return checker.SecurityPolicyData{}, err
 	}
-	// If we found files in the repo, return immediately.
-	if data.file.Type != checker.FileTypeNone {
-		err := fileparser.OnMatchingFileContentDo(c.RepoClient, fileparser.PathMatcher{
-			Pattern:       data.file.Path,
-			CaseSensitive: false,
-		}, checkSecurityPolicyFileContent, &data.file, &data.info)
-		if err != nil {
+
+	// If we found files in the repo, fill in their content and return
+	// immediately: a repo-local policy always takes precedence over the
+	// org-level fallback, however many subproject policies there are.
+	if len(data.policies) > 0 {
+		if err := fillSecurityPolicyContent(c.RepoClient, &data); err != nil {
 			return checker.SecurityPolicyData{}, err
 		}
-		return checker.SecurityPolicyData{
-			File:                  data.file,
-			SecurityContentLength: data.file.EndOffset,
-			Information:           data.info,
-		}, nil
+		return checker.SecurityPolicyData{PolicyFiles: data.policies}, nil
 	}
 
-	// Check if present in parent org.
-	// https#://docs.github.com/en/github/building-a-strong-community/creating-a-default-community-health-file.
-	// TODO(1491): Make this non-GitHub specific.
-	logger := log.NewLogger(log.InfoLevel)
-	dotGitHubClient := githubrepo.CreateGithubRepoClient(c.Ctx, logger)
-	err = dotGitHubClient.InitRepo(c.Repo.Org(), clients.HeadSHA)
+	// Check if present in the org/group's health-file repo, e.g.
+	// "owner/.github" on GitHub or "group/.gitlab" on GitLab.
+	// https://docs.github.com/en/github/building-a-strong-community/creating-a-default-community-health-file.
+	orgClient, err := c.RepoClient.OrgRepoClient(c.Ctx)
 	switch {
 	case err == nil:
-		defer dotGitHubClient.Close()
-		data.uri = dotGitHubClient.URI()
-		err = fileparser.OnAllFilesDo(dotGitHubClient, isSecurityPolicyFile, &data)
+		defer orgClient.Close()
+		data.uri = orgClient.URI()
+		err = fileparser.OnAllFilesDo(orgClient, isSecurityPolicyFile, &data)
 		if err != nil {
 			return checker.SecurityPolicyData{}, err
 		}
 
-	case errors.Is(err, sce.ErrRepoUnreachable):
+	case errors.Is(err, sce.ErrRepoUnreachable), errors.Is(err, clients.ErrUnsupportedFeature):
 		break
 	default:
 		return checker.SecurityPolicyData{}, err
 	}
 
-	// Return raw results.
-	if data.file.Type != checker.FileTypeNone {
-		filePattern := data.file.Path
+	if len(data.policies) > 0 {
+		if err := fillSecurityPolicyContent(orgClient, &data); err != nil {
+			return checker.SecurityPolicyData{}, err
+		}
+	}
+	return checker.SecurityPolicyData{PolicyFiles: data.policies}, nil
+}
+
+// fillSecurityPolicyContent reads each collected policy file's content and
+// records its length and any disclosure information found in it.
+func fillSecurityPolicyContent(rc clients.RepoClient, data *securityPolicyFilesWithURI) error {
+	for i := range data.policies {
+		policy := &data.policies[i]
+		filePattern := policy.File.Path
 		// undo path.Join in isSecurityPolicyFile
-		if data.file.Type == checker.FileTypeURL {
-			filePattern = strings.Replace(data.file.Path, data.uri+"/", "", 1)
+		if policy.File.Type == checker.FileTypeURL {
+			filePattern = strings.Replace(policy.File.Path, data.uri+"/", "", 1)
 		}
-		err := fileparser.OnMatchingFileContentDo(dotGitHubClient, fileparser.PathMatcher{
+		err := fileparser.OnMatchingFileContentDo(rc, fileparser.PathMatcher{
 			Pattern:       filePattern,
 			CaseSensitive: false,
-		}, checkSecurityPolicyFileContent, &data.file, &data.info)
+		}, checkSecurityPolicyFileContent, &policy.File, &policy.Information)
 		if err != nil {
-			return checker.SecurityPolicyData{}, err
+			return err
 		}
 	}
-	return checker.SecurityPolicyData{
-		File:                  data.file,
-		SecurityContentLength: data.file.EndOffset,
-		Information:           data.info,
-	}, nil
+	return nil
 }
 
 // Check repository for repository-specific policy.
@@ -134,18 +126,36 @@ var isSecurityPolicyFile fileparser.DoWhileTrueOnFilename = func(name string, ar
 			// than the repo level
 			tempType = checker.FileTypeURL
 		}
-		pdata.file = checker.File{
-			Path:   tempPath,
-			Type:   tempType,
-			Offset: checker.OffsetDefault,
-		}
-		return false, nil
+		pdata.policies = append(pdata.policies, checker.SecurityPolicyFile{
+			File: checker.File{
+				Path:   tempPath,
+				Type:   tempType,
+				Offset: checker.OffsetDefault,
+			},
+			Information: make([]checker.SecurityPolicyInformation, 0),
+			Root:        securityPolicyRoot(name),
+		})
+		// Keep scanning: a monorepo may have more than one policy.
+		return true, nil
 	}
 	return true, nil
 }
 
+// securityPolicyRoot returns the directory a security policy match governs:
+// the nearest ancestor of the matched path, with well-known conventional
+// directories (.github, docs, doc) treated as governing the whole repo.
+func securityPolicyRoot(name string) string {
+	dir := path.Dir(name)
+	switch dir {
+	case ".", ".github", "docs", "doc":
+		return "."
+	default:
+		return dir
+	}
+}
+
 func isSecurityPolicyFilename(name string) bool {
-	return strings.EqualFold(name, "security.md") ||
+	if strings.EqualFold(name, "security.md") ||
 		strings.EqualFold(name, ".github/security.md") ||
 		strings.EqualFold(name, "docs/security.md") ||
 		strings.EqualFold(name, "security.adoc") ||
@@ -154,7 +164,17 @@ func isSecurityPolicyFilename(name string) bool {
 		strings.EqualFold(name, "security.rst") ||
 		strings.EqualFold(name, ".github/security.rst") ||
 		strings.EqualFold(name, "doc/security.rst") ||
-		strings.EqualFold(name, "docs/security.rst")
+		strings.EqualFold(name, "docs/security.rst") {
+		return true
+	}
+	// A nested "<subproject>/SECURITY.md" covers per-project policies in a
+	// monorepo; the root-level cases above are already handled so only
+	// match here when the file is below some subdirectory.
+	base := path.Base(name)
+	return strings.Contains(name, "/") &&
+		(strings.EqualFold(base, "security.md") ||
+			strings.EqualFold(base, "security.adoc") ||
+			strings.EqualFold(base, "security.rst"))
 }
 
 var checkSecurityPolicyFileContent fileparser.DoWhileTrueOnFileContent = func(path string, content []byte,
@@ -0,0 +1,47 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+// CIConfig is a normalized CI pipeline configuration, independent of the
+// forge-specific dialect it was parsed from (GitHub Actions workflows,
+// GitLab CI pipelines, ...). Checks that need to reason about pipeline
+// behavior (Dangerous-Workflow, Token-Permissions) work off this
+// abstraction so the same heuristics apply everywhere a RepoClient can
+// surface a CI config.
+type CIConfig struct {
+	// Path is the file the config was parsed from, e.g. ".gitlab-ci.yml"
+	// or ".github/workflows/build.yml".
+	Path string
+	Jobs []CIJob
+}
+
+// CIJob is a single job/workflow-step within a CIConfig, flattened from
+// whatever include/reusable-workflow mechanism the forge supports.
+type CIJob struct {
+	Name         string
+	Image        string
+	Services     []string
+	Script       []string
+	BeforeScript []string
+	Rules        []string
+	Only         []string
+	Except       []string
+	// UsesJobToken is true when the job references a scoped, short-lived
+	// CI job token (CI_JOB_TOKEN on GitLab, GITHUB_TOKEN on GitHub).
+	UsesJobToken bool
+	// UsesIDTokens is true when the job requests an OIDC/ID token
+	// (id_tokens: on GitLab, permissions: id-token on GitHub).
+	UsesIDTokens bool
+}
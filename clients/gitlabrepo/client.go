@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/xanzy/go-gitlab"
@@ -51,9 +52,9 @@ type Client struct {
 	webhook       *webhookHandler
 	languages     *languagesHandler
 	licenses      *licensesHandler
+	tarball       tarballHandler
 	ctx           context.Context
-	// tarball       tarballHandler
-	commitDepth int
+	commitDepth   int
 }
 
 // InitRepo sets up the GitLab project in local storage for improving performance and GitLab token usage efficiency.
@@ -81,8 +82,18 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 		commitSHA:     commitSHA,
 	}
 
-	if repo.Owner != nil {
+	// repo.Owner is only populated for personal-namespace projects; for the
+	// common case of a group-owned project, fall back to the top-level
+	// group, i.e. the first path segment of PathWithNamespace (e.g. "group"
+	// in "group/subgroup/project"), since Namespace only reports the
+	// immediate parent, not necessarily the top-level group.
+	switch {
+	case repo.Owner != nil:
 		client.repourl.owner = repo.Owner.Name
+	case repo.PathWithNamespace != "":
+		client.repourl.owner = strings.SplitN(repo.PathWithNamespace, "/", 2)[0]
+	case repo.Namespace != nil:
+		client.repourl.owner = repo.Namespace.Path
 	}
 
 	// Init contributorsHandler
@@ -128,7 +139,7 @@ func (client *Client) InitRepo(inputRepo clients.Repo, commitSHA string, commitD
 	client.licenses.init(client.repourl)
 
 	// Init tarballHandler.
-	// client.tarball.init(client.ctx, client.repourl, client.repo, commitSHA)
+	client.tarball.init(client.ctx, client.repourl, commitSHA)
 	return nil
 }
 
@@ -137,11 +148,11 @@ func (client *Client) URI() string {
 }
 
 func (client *Client) ListFiles(predicate func(string) (bool, error)) ([]string, error) {
-	return nil, nil
+	return client.tarball.listFiles(predicate)
 }
 
 func (client *Client) GetFileContent(filename string) ([]byte, error) {
-	return nil, nil
+	return client.tarball.getFile(filename)
 }
 
 func (client *Client) ListCommits() ([]clients.Commit, error) {
@@ -188,6 +199,13 @@ func (client *Client) ListSuccessfulWorkflowRuns(filename string) ([]clients.Wor
 	return client.workflows.listSuccessfulWorkflowRuns(filename)
 }
 
+// GetCIConfig implements RepoClient.GetCIConfig, returning the repo's
+// GitLab CI pipeline parsed into the forge-independent clients.CIConfig.
+// Returns (nil, nil) when the repo has no .gitlab-ci.yml.
+func (client *Client) GetCIConfig() (*clients.CIConfig, error) {
+	return client.workflows.ciConfig()
+}
+
 func (client *Client) ListCheckRunsForRef(ref string) ([]clients.CheckRun, error) {
 	return client.checkruns.listCheckRunsForRef(ref)
 }
@@ -214,58 +232,99 @@ func (client *Client) SearchCommits(request clients.SearchCommitsOptions) ([]cli
 }
 
 func (client *Client) Close() error {
-	return nil
+	return client.tarball.cleanup()
 }
 
-func CreateGitlabClientWithToken(ctx context.Context, token string, repo clients.Repo) (clients.RepoClient, error) {
-	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(repo.URI()))
-	if err != nil {
-		return nil, fmt.Errorf("could not create gitlab client with error: %w", err)
-	}
-
-	return &Client{
+// newClient builds a Client with all of its handlers wired to the same
+// underlying gitlab API client. Shared so OrgRepoClient can hand back a
+// second Client (scoped to the org/group health repo) without a second
+// token or round-tripper.
+func newClient(ctx context.Context, glClient *gitlab.Client) *Client {
+	client := &Client{
 		ctx:      ctx,
-		glClient: client,
+		glClient: glClient,
 		contributors: &contributorsHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		branches: &branchesHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		releases: &releasesHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		workflows: &workflowsHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		checkruns: &checkrunsHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		commits: &commitsHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		issues: &issuesHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		project: &projectHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		statuses: &statusesHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		search: &searchHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		searchCommits: &searchCommitsHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		webhook: &webhookHandler{
-			glClient: client,
+			glClient: glClient,
 		},
 		languages: &languagesHandler{
-			glClient: client,
+			glClient: glClient,
 		},
-	}, nil
+		tarball: tarballHandler{
+			glClient: glClient,
+		},
+	}
+	client.licenses = &licensesHandler{
+		glClient: glClient,
+		tarball:  &client.tarball,
+	}
+	client.workflows.tarball = &client.tarball
+	return client
+}
+
+func CreateGitlabClientWithToken(ctx context.Context, token string, repo clients.Repo) (clients.RepoClient, error) {
+	client, err := gitlab.NewClient(token, gitlab.WithBaseURL(repo.URI()))
+	if err != nil {
+		return nil, fmt.Errorf("could not create gitlab client with error: %w", err)
+	}
+
+	return newClient(ctx, client), nil
+}
+
+// OrgRepoClient implements RepoClient.OrgRepoClient. It returns a Client
+// scoped to the top-level group's conventional health-file project, which
+// GitLab.com and most self-hosted instances resolve as "<group>/.gitlab".
+func (client *Client) OrgRepoClient(ctx context.Context) (clients.RepoClient, error) {
+	if client.repourl == nil || client.repourl.owner == "" {
+		return nil, sce.WithMessage(clients.ErrUnsupportedFeature, "no group found for this project")
+	}
+
+	orgRepo := &repoURL{
+		hostname:  client.repourl.hostname,
+		owner:     client.repourl.owner,
+		projectID: fmt.Sprintf("%s/.gitlab", client.repourl.owner),
+	}
+
+	orgClient := newClient(ctx, client.glClient)
+	if err := orgClient.InitRepo(orgRepo, clients.HeadSHA, 0); err != nil {
+		if errors.Is(err, sce.ErrRepoUnreachable) {
+			return nil, err
+		}
+		return nil, sce.WithMessage(sce.ErrRepoUnreachable, err.Error())
+	}
+	return orgClient, nil
 }
 
 // TODO(#2266): implement CreateOssFuzzRepoClient.
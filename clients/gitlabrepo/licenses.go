@@ -0,0 +1,74 @@
+// Copyright 2022 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlabrepo
+
+import (
+	"fmt"
+
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/ossf/scorecard/v4/clients"
+	"github.com/ossf/scorecard/v4/clients/license"
+)
+
+// licensesHandler reports the repo's license(s). GitLab project metadata
+// only exposes the license GitLab itself recognized (and nothing at all on
+// many self-hosted instances), so detection is done by scanning the repo's
+// files with the shared license classifier instead.
+type licensesHandler struct {
+	glClient *gitlab.Client
+	repourl  *repoURL
+	tarball  *tarballHandler
+	once     detectorOnce
+}
+
+// detectorOnce lazily builds the (relatively expensive) classifier at most
+// once per handler.
+type detectorOnce struct {
+	detector *license.Detector
+	err      error
+	done     bool
+}
+
+func (h *licensesHandler) init(repourl *repoURL) {
+	h.repourl = repourl
+	h.once = detectorOnce{}
+}
+
+func (h *licensesHandler) listLicenses() ([]clients.License, error) {
+	if !h.once.done {
+		h.once.detector, h.once.err = license.NewDetector(license.DefaultConfidenceThreshold)
+		h.once.done = true
+	}
+	if h.once.err != nil {
+		return nil, fmt.Errorf("license.NewDetector: %w", h.once.err)
+	}
+
+	licenses, err := h.once.detector.Detect(h.listFiles, h.getFileContent)
+	if err != nil {
+		return nil, fmt.Errorf("detecting licenses: %w", err)
+	}
+	return licenses, nil
+}
+
+// listFiles and getFileContent let licensesHandler reuse the package's
+// tarball-backed file access without depending on the full Client type.
+func (h *licensesHandler) listFiles(predicate func(string) (bool, error)) ([]string, error) {
+	return h.tarball.listFiles(predicate)
+}
+
+func (h *licensesHandler) getFileContent(filename string) ([]byte, error) {
+	return h.tarball.getFile(filename)
+}
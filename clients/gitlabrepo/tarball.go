@@ -0,0 +1,260 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlabrepo
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/xanzy/go-gitlab"
+
+	sce "github.com/ossf/scorecard/v4/errors"
+)
+
+// maxArchiveFileSize bounds the size of a single file we will extract from
+// the archive, to avoid decompression bombs.
+const maxArchiveFileSize = 100 * 1024 * 1024 // 100MB
+
+var errTarballNotExtracted = errors.New("tarball not yet extracted")
+
+// tarballHandler downloads the repo archive at a given commit and serves
+// files out of the extracted tree. It mirrors githubrepo's tarballHandler.
+type tarballHandler struct {
+	glClient  *gitlab.Client
+	once      *sync.Once
+	ctx       context.Context
+	repourl   *repoURL
+	commitSHA string
+	tempDir   string
+	files     []string
+	setupErr  error
+}
+
+func (t *tarballHandler) init(ctx context.Context, repourl *repoURL, commitSHA string) {
+	t.ctx = ctx
+	t.repourl = repourl
+	t.commitSHA = commitSHA
+	t.once = new(sync.Once)
+	t.tempDir = ""
+	t.files = nil
+	t.setupErr = nil
+}
+
+func (t *tarballHandler) setup() error {
+	t.once.Do(func() {
+		t.setupErr = t.getTarball()
+		if t.setupErr != nil {
+			return
+		}
+		t.setupErr = t.extractTarball()
+	})
+	return t.setupErr
+}
+
+// getTarball downloads the project archive at commitSHA and writes it to a
+// temp file.
+func (t *tarballHandler) getTarball() error {
+	tempDir, err := os.MkdirTemp("", "gitlabrepo-tarball")
+	if err != nil {
+		return fmt.Errorf("os.MkdirTemp: %w", err)
+	}
+	t.tempDir = tempDir
+
+	format := "tar.gz"
+	archiveBytes, _, err := t.glClient.Repositories.Archive(t.repourl.projectID, &gitlab.ArchiveOptions{
+		Format: &format,
+		SHA:    &t.commitSHA,
+	}, nil, gitlab.WithContext(t.ctx))
+	if err != nil {
+		return sce.WithMessage(sce.ErrRepoUnreachable, fmt.Sprintf("Repositories.Archive: %v", err))
+	}
+
+	tarPath := filepath.Join(t.tempDir, "archive.tar.gz")
+	// nolint: gomnd
+	if err := os.WriteFile(tarPath, archiveBytes, 0o644); err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+	return nil
+}
+
+// extractTarball extracts the downloaded archive into t.tempDir and records
+// the list of regular files found, relative to the archive root.
+func (t *tarballHandler) extractTarball() error {
+	tarPath := filepath.Join(t.tempDir, "archive.tar.gz")
+	f, err := os.Open(tarPath)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	gzr, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("gzip.NewReader: %w", err)
+	}
+	defer gzr.Close()
+
+	extractDir := filepath.Join(t.tempDir, "extracted")
+	if err := os.Mkdir(extractDir, 0o755); err != nil {
+		return fmt.Errorf("os.Mkdir: %w", err)
+	}
+
+	tr := tar.NewReader(gzr)
+	var rootPrefix string
+	var files []string
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("tar.Reader.Next: %w", err)
+		}
+
+		// GitLab archives wrap all content in a single top-level directory
+		// named "<project>-<ref>-<sha>/". Strip it so paths match what
+		// ListFiles callers expect (relative to the repo root).
+		name := header.Name
+		if rootPrefix == "" {
+			if idx := strings.IndexRune(name, '/'); idx >= 0 {
+				rootPrefix = name[:idx+1]
+			}
+		}
+		relPath := strings.TrimPrefix(name, rootPrefix)
+		if relPath == "" {
+			continue
+		}
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			continue
+		case tar.TypeSymlink, tar.TypeLink:
+			// Skip symlinks: we don't want to follow them outside the
+			// extraction directory.
+			continue
+		case tar.TypeReg:
+			if header.Size > maxArchiveFileSize {
+				continue
+			}
+			destPath := filepath.Join(extractDir, relPath)
+			// Guard against zip-slip: a tar entry name containing "../"
+			// segments must not resolve outside extractDir.
+			if !isWithinDir(extractDir, destPath) {
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(destPath), 0o755); err != nil {
+				return fmt.Errorf("os.MkdirAll: %w", err)
+			}
+			out, err := os.OpenFile(destPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+			if err != nil {
+				return fmt.Errorf("os.OpenFile: %w", err)
+			}
+			// nolint: gosec
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return fmt.Errorf("io.Copy: %w", err)
+			}
+			out.Close()
+			files = append(files, relPath)
+		default:
+			continue
+		}
+	}
+
+	t.tempDir = extractDir
+	t.files = files
+	return nil
+}
+
+// isWithinDir reports whether path is dir or a descendant of it, after
+// cleaning both. Used to reject tar entries that would otherwise extract
+// outside the intended directory via "../" segments in their name.
+func isWithinDir(dir, path string) bool {
+	dir = filepath.Clean(dir)
+	path = filepath.Clean(path)
+	if path == dir {
+		return true
+	}
+	return strings.HasPrefix(path, dir+string(filepath.Separator))
+}
+
+func (t *tarballHandler) listFiles(predicate func(string) (bool, error)) ([]string, error) {
+	if err := t.setup(); err != nil {
+		return nil, fmt.Errorf("error during tarballHandler.setup: %w", err)
+	}
+
+	var matched []string
+	for _, file := range t.files {
+		ok, err := predicate(file)
+		if err != nil {
+			return nil, fmt.Errorf("error applying predicate to file %q: %w", file, err)
+		}
+		if ok {
+			matched = append(matched, file)
+		}
+	}
+	return matched, nil
+}
+
+func (t *tarballHandler) getFile(filename string) ([]byte, error) {
+	if err := t.setup(); err != nil {
+		return nil, fmt.Errorf("error during tarballHandler.setup: %w", err)
+	}
+	if t.tempDir == "" {
+		return nil, errTarballNotExtracted
+	}
+
+	// filename is expected to use forward slashes, matching ListFiles output.
+	path := filepath.Join(t.tempDir, filepath.FromSlash(filename))
+	info, err := os.Lstat(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Lstat: %w", err)
+	}
+	if info.Mode()&os.ModeSymlink != 0 {
+		return nil, fmt.Errorf("%w: %s is a symlink", errTarballNotExtracted, filename)
+	}
+	if info.Size() > maxArchiveFileSize {
+		return nil, fmt.Errorf("file %s exceeds max size of %d bytes", filename, maxArchiveFileSize)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+	return content, nil
+}
+
+func (t *tarballHandler) cleanup() error {
+	if t.tempDir != "" {
+		// t.tempDir may point at the "extracted" subdirectory; remove its
+		// parent so the tar.gz download is cleaned up too.
+		root := t.tempDir
+		if filepath.Base(root) == "extracted" {
+			root = filepath.Dir(root)
+		}
+		if err := os.RemoveAll(root); err != nil {
+			return fmt.Errorf("os.RemoveAll: %w", err)
+		}
+	}
+	t.once = new(sync.Once)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlabrepo
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+// buildFixtureArchive writes a GitLab-style "<project>-<ref>-<sha>/"-rooted
+// tar.gz containing the given files, returning its path.
+func buildFixtureArchive(t *testing.T, dir string, files map[string]string) string {
+	t.Helper()
+
+	archivePath := filepath.Join(dir, "archive.tar.gz")
+	f, err := os.Create(archivePath)
+	if err != nil {
+		t.Fatalf("os.Create: %v", err)
+	}
+	defer f.Close()
+
+	gzw := gzip.NewWriter(f)
+	defer gzw.Close()
+	tw := tar.NewWriter(gzw)
+	defer tw.Close()
+
+	const root = "scorecard-main-deadbeef/"
+	for name, content := range files {
+		hdr := &tar.Header{
+			Name: root + name,
+			Mode: 0o644,
+			Size: int64(len(content)),
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			t.Fatalf("tw.WriteHeader: %v", err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatalf("tw.Write: %v", err)
+		}
+	}
+	return archivePath
+}
+
+func Test_tarballHandler_extractAndList(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	buildFixtureArchive(t, tempDir, map[string]string{
+		"README.md":           "# hello",
+		"SECURITY.md":         "report to security@example.com",
+		".github/FUNDING.yml": "github: []",
+	})
+
+	handler := &tarballHandler{tempDir: tempDir}
+	if err := handler.extractTarball(); err != nil {
+		t.Fatalf("extractTarball: %v", err)
+	}
+	// Mark setup as already done so listFiles/getFile don't try to
+	// re-download the archive over the network.
+	handler.once = new(sync.Once)
+	handler.once.Do(func() {})
+
+	files, err := handler.listFiles(func(string) (bool, error) { return true, nil })
+	if err != nil {
+		t.Fatalf("listFiles: %v", err)
+	}
+	if len(files) != 3 {
+		t.Errorf("expected 3 files, got %d: %v", len(files), files)
+	}
+
+	content, err := handler.getFile("SECURITY.md")
+	if err != nil {
+		t.Fatalf("getFile: %v", err)
+	}
+	if !bytes.Contains(content, []byte("security@example.com")) {
+		t.Errorf("unexpected content: %s", content)
+	}
+}
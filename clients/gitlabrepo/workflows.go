@@ -0,0 +1,366 @@
+// Copyright 2022 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlabrepo
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+	"gopkg.in/yaml.v3"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+const gitlabCIPath = ".gitlab-ci.yml"
+
+// gitlabTemplatesProject is the official project GitLab resolves bare
+// `template:` includes against, e.g. `template: Security/SAST.gitlab-ci.yml`
+// resolves to "lib/gitlab/ci/templates/Security/SAST.gitlab-ci.yml" in it.
+// https://docs.gitlab.com/ee/ci/yaml/includes.html#include-a-cicd-template
+const gitlabTemplatesProject = "gitlab-org/gitlab"
+
+type workflowsHandler struct {
+	glClient *gitlab.Client
+	repourl  *repoURL
+	tarball  *tarballHandler
+}
+
+func (h *workflowsHandler) init(repourl *repoURL) {
+	h.repourl = repourl
+}
+
+func (h *workflowsHandler) listSuccessfulWorkflowRuns(filename string) ([]clients.WorkflowRun, error) {
+	opts := &gitlab.ListProjectPipelinesOptions{
+		Status: gitlab.Ptr(gitlab.SuccessStatus),
+	}
+	pipelines, _, err := h.glClient.Pipelines.ListProjectPipelines(h.repourl.projectID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("Pipelines.ListProjectPipelines: %w", err)
+	}
+
+	var runs []clients.WorkflowRun
+	for _, p := range pipelines {
+		runs = append(runs, clients.WorkflowRun{
+			URL:     p.WebURL,
+			HeadSHA: p.SHA,
+		})
+	}
+	return runs, nil
+}
+
+// ciConfig parses .gitlab-ci.yml (and any local/project/remote includes)
+// into the normalized clients.CIConfig, so Dangerous-Workflow and
+// Token-Permissions can run the same heuristics they use for
+// .github/workflows/*.yml against GitLab pipelines.
+func (h *workflowsHandler) ciConfig() (*clients.CIConfig, error) {
+	root, err := h.tarball.getFile(gitlabCIPath)
+	if err != nil {
+		//nolint:nilnil // no .gitlab-ci.yml is not an error, just nothing to report.
+		return nil, nil
+	}
+
+	doc, err := parseGitlabCIYaml(root)
+	if err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal %s: %w", gitlabCIPath, err)
+	}
+
+	cfg := &clients.CIConfig{Path: gitlabCIPath}
+	seen := map[string]bool{"local:" + gitlabCIPath: true}
+	if err := h.collectJobs(doc, cfg, seen); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// gitlabCIYaml is a loose parse of a .gitlab-ci.yml document: everything
+// that isn't a reserved top-level keyword is treated as a job.
+type gitlabCIYaml struct {
+	Include yaml.Node
+	Jobs    map[string]gitlabJob
+}
+
+// parseGitlabCIYaml decodes a .gitlab-ci.yml document in two passes. A
+// single-pass decode into a struct with a `yaml:",inline"` job map fails on
+// almost any real file: reserved top-level keys like `stages: [...]` or a
+// string-shorthand `image: foo` don't unmarshal into gitlabJob and yaml.v3
+// errors out before reserved-key filtering ever runs. Decoding into
+// map[string]yaml.Node first lets reserved/hidden keys be dropped before
+// the remaining nodes are decoded as jobs.
+func parseGitlabCIYaml(content []byte) (gitlabCIYaml, error) {
+	var raw map[string]yaml.Node
+	if err := yaml.Unmarshal(content, &raw); err != nil {
+		return gitlabCIYaml{}, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+
+	doc := gitlabCIYaml{Jobs: map[string]gitlabJob{}}
+	for name, node := range raw {
+		if name == "include" {
+			doc.Include = node
+			continue
+		}
+		if reservedGitlabKeys[strings.ToLower(name)] || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		var job gitlabJob
+		if err := node.Decode(&job); err != nil {
+			// Not a job (e.g. an unrecognized reserved key or an
+			// extends anchor): skip rather than fail the whole file.
+			continue
+		}
+		doc.Jobs[name] = job
+	}
+	return doc, nil
+}
+
+type gitlabJob struct {
+	Image        interface{} `yaml:"image"`
+	Services     []string    `yaml:"services"`
+	Script       stringList  `yaml:"script"`
+	BeforeScript stringList  `yaml:"before_script"`
+	Rules        yaml.Node   `yaml:"rules"`
+	Only         stringList  `yaml:"only"`
+	Except       stringList  `yaml:"except"`
+	IDTokens     yaml.Node   `yaml:"id_tokens"`
+}
+
+// stringList unmarshals either a single scalar or a list of scalars, since
+// .gitlab-ci.yml allows both forms for script/only/except.
+type stringList []string
+
+func (s *stringList) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		var v string
+		if err := node.Decode(&v); err != nil {
+			return fmt.Errorf("decode scalar: %w", err)
+		}
+		*s = []string{v}
+	case yaml.SequenceNode:
+		var v []string
+		if err := node.Decode(&v); err != nil {
+			return fmt.Errorf("decode sequence: %w", err)
+		}
+		*s = v
+	}
+	return nil
+}
+
+var reservedGitlabKeys = map[string]bool{
+	"stages": true, "variables": true, "workflow": true, "default": true,
+	"include": true, "image": true, "services": true, "before_script": true,
+	"after_script": true, "cache": true,
+}
+
+func (h *workflowsHandler) collectJobs(doc gitlabCIYaml, cfg *clients.CIConfig, seen map[string]bool) error {
+	for name, job := range doc.Jobs {
+		image, _ := job.Image.(string)
+		cfg.Jobs = append(cfg.Jobs, clients.CIJob{
+			Name:         name,
+			Image:        image,
+			Services:     job.Services,
+			Script:       job.Script,
+			BeforeScript: job.BeforeScript,
+			Rules:        jobRules(job.Rules),
+			Only:         job.Only,
+			Except:       job.Except,
+			UsesJobToken: usesJobToken(job.Script) || usesJobToken(job.BeforeScript),
+			UsesIDTokens: job.IDTokens.Content != nil,
+		})
+	}
+
+	return h.resolveIncludes(doc.Include, cfg, seen)
+}
+
+// jobRules flattens a job's `rules:` node (a list of condition maps) into
+// the `if` expression of each rule, mirroring how Only/Except are reported
+// as plain strings.
+func jobRules(rules yaml.Node) []string {
+	if rules.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var out []string
+	for _, r := range rules.Content {
+		var rule struct {
+			If string `yaml:"if"`
+		}
+		if err := r.Decode(&rule); err != nil || rule.If == "" {
+			continue
+		}
+		out = append(out, rule.If)
+	}
+	return out
+}
+
+// includeEntry is one entry of an `include:` list. A bare scalar entry
+// (e.g. `include: '/ci/jobs.yml'`) is equivalent to {local: '/ci/jobs.yml'}.
+// File is a stringList because `project:`/`template:` includes may name
+// either a single file or a list of files to include from the same project.
+type includeEntry struct {
+	Local    string     `yaml:"local"`
+	Project  string     `yaml:"project"`
+	Ref      string     `yaml:"ref"`
+	File     stringList `yaml:"file"`
+	Remote   string     `yaml:"remote"`
+	Template string     `yaml:"template"`
+}
+
+// resolveIncludes follows local, project, remote, and template includes,
+// merging their jobs into cfg. Cycles and already-visited files are
+// skipped via seen.
+func (h *workflowsHandler) resolveIncludes(include yaml.Node, cfg *clients.CIConfig, seen map[string]bool) error {
+	if include.Kind == 0 {
+		return nil
+	}
+
+	var entries []includeEntry
+	switch include.Kind {
+	case yaml.ScalarNode:
+		var local string
+		if err := include.Decode(&local); err != nil {
+			return fmt.Errorf("decode include: %w", err)
+		}
+		entries = []includeEntry{{Local: local}}
+	case yaml.SequenceNode:
+		for _, node := range include.Content {
+			if node.Kind == yaml.ScalarNode {
+				var local string
+				if err := node.Decode(&local); err != nil {
+					return fmt.Errorf("decode include: %w", err)
+				}
+				entries = append(entries, includeEntry{Local: local})
+				continue
+			}
+			var entry includeEntry
+			if err := node.Decode(&entry); err != nil {
+				return fmt.Errorf("decode include entry: %w", err)
+			}
+			entries = append(entries, entry)
+		}
+	}
+
+	for _, entry := range entries {
+		switch {
+		case entry.Local != "":
+			if err := h.resolveLocalInclude(entry.Local, cfg, seen); err != nil {
+				return err
+			}
+		case entry.Project != "":
+			if err := h.resolveProjectInclude(entry.Project, entry.Ref, entry.File, cfg, seen); err != nil {
+				return err
+			}
+		case entry.Template != "":
+			if err := h.resolveProjectInclude(gitlabTemplatesProject, "",
+				stringList{"lib/gitlab/ci/templates/" + entry.Template}, cfg, seen); err != nil {
+				return err
+			}
+		case entry.Remote != "":
+			if err := h.resolveRemoteInclude(entry.Remote, cfg, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (h *workflowsHandler) resolveLocalInclude(local string, cfg *clients.CIConfig, seen map[string]bool) error {
+	local = strings.TrimPrefix(local, "/")
+	key := "local:" + local
+	if seen[key] {
+		return nil
+	}
+	seen[key] = true
+
+	content, err := h.tarball.getFile(local)
+	if err != nil {
+		return nil
+	}
+	return h.mergeInclude(content, local, cfg, seen)
+}
+
+func (h *workflowsHandler) resolveProjectInclude(
+	project, ref string, files stringList, cfg *clients.CIConfig, seen map[string]bool,
+) error {
+	opts := &gitlab.GetRawFileOptions{}
+	if ref != "" {
+		opts.Ref = gitlab.Ptr(ref)
+	}
+	for _, file := range files {
+		file = strings.TrimPrefix(file, "/")
+		key := fmt.Sprintf("project:%s@%s:%s", project, ref, file)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		content, _, err := h.glClient.RepositoryFiles.GetRawFile(project, file, opts)
+		if err != nil {
+			// Included project/ref/file may be private or gone; skip
+			// rather than fail the whole config.
+			continue
+		}
+		if err := h.mergeInclude(content, file, cfg, seen); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *workflowsHandler) resolveRemoteInclude(remoteURL string, cfg *clients.CIConfig, seen map[string]bool) error {
+	key := "remote:" + remoteURL
+	if seen[key] {
+		return nil
+	}
+	seen[key] = true
+
+	//nolint:gosec // the URL comes from the repo's own .gitlab-ci.yml, same trust level as its jobs' scripts.
+	resp, err := http.Get(remoteURL)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil
+	}
+	return h.mergeInclude(content, remoteURL, cfg, seen)
+}
+
+// mergeInclude parses an included document's content and merges its jobs
+// (and any includes of its own) into cfg.
+func (h *workflowsHandler) mergeInclude(
+	content []byte, source string, cfg *clients.CIConfig, seen map[string]bool,
+) error {
+	included, err := parseGitlabCIYaml(content)
+	if err != nil {
+		return fmt.Errorf("yaml.Unmarshal %s: %w", source, err)
+	}
+	return h.collectJobs(included, cfg, seen)
+}
+
+func usesJobToken(lines []string) bool {
+	for _, line := range lines {
+		if strings.Contains(line, "CI_JOB_TOKEN") {
+			return true
+		}
+	}
+	return false
+}
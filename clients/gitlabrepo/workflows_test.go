@@ -0,0 +1,134 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gitlabrepo
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+func Test_parseGitlabCIYaml(t *testing.T) {
+	t.Parallel()
+
+	// stages is a sequence and image is a bare scalar: both are
+	// near-universal in real .gitlab-ci.yml files and must not be mistaken
+	// for jobs or make the parse fail outright.
+	const doc = `
+stages:
+  - build
+  - test
+image: golang:1.20
+
+build:
+  script:
+    - go build ./...
+
+test:
+  image:
+    name: golang:1.20
+  rules:
+    - if: '$CI_MERGE_REQUEST_ID'
+  script: go test ./...
+
+.hidden:
+  script:
+    - echo not a real job
+`
+
+	parsed, err := parseGitlabCIYaml([]byte(doc))
+	if err != nil {
+		t.Fatalf("parseGitlabCIYaml: %v", err)
+	}
+
+	var names []string
+	for name := range parsed.Jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	want := []string{"build", "test"}
+	if len(names) != len(want) || names[0] != want[0] || names[1] != want[1] {
+		t.Fatalf("expected jobs %v, got %v", want, names)
+	}
+
+	testJob := parsed.Jobs["test"]
+	if len(testJob.Script) != 1 || testJob.Script[0] != "go test ./..." {
+		t.Errorf("unexpected script for job test: %v", testJob.Script)
+	}
+}
+
+func Test_workflowsHandler_ciConfig_resolvesLocalIncludes(t *testing.T) {
+	t.Parallel()
+
+	tempDir := t.TempDir()
+	buildFixtureArchive(t, tempDir, map[string]string{
+		".gitlab-ci.yml": `
+stages:
+  - build
+
+include:
+  - local: '/ci/jobs.yml'
+
+build:
+  script:
+    - go build ./...
+`,
+		"ci/jobs.yml": `
+deploy:
+  rules:
+    - if: '$CI_COMMIT_BRANCH == "main"'
+  script:
+    - CI_JOB_TOKEN=$CI_JOB_TOKEN ./deploy.sh
+`,
+	})
+
+	tarball := &tarballHandler{tempDir: tempDir}
+	if err := tarball.extractTarball(); err != nil {
+		t.Fatalf("extractTarball: %v", err)
+	}
+	// Mark setup as already done so ciConfig doesn't try to re-download
+	// the archive over the network.
+	tarball.once = new(sync.Once)
+	tarball.once.Do(func() {})
+
+	h := &workflowsHandler{tarball: tarball}
+	cfg, err := h.ciConfig()
+	if err != nil {
+		t.Fatalf("ciConfig: %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("expected a non-nil CIConfig")
+	}
+
+	jobs := map[string]bool{}
+	var deployRules []string
+	var deployUsesJobToken bool
+	for _, job := range cfg.Jobs {
+		jobs[job.Name] = true
+		if job.Name == "deploy" {
+			deployRules = job.Rules
+			deployUsesJobToken = job.UsesJobToken
+		}
+	}
+	if !jobs["build"] || !jobs["deploy"] {
+		t.Fatalf("expected jobs build and deploy, got %v", jobs)
+	}
+	if len(deployRules) != 1 || deployRules[0] != `$CI_COMMIT_BRANCH == "main"` {
+		t.Errorf("unexpected rules for job deploy: %v", deployRules)
+	}
+	if !deployUsesJobToken {
+		t.Error("expected deploy job to report UsesJobToken")
+	}
+}
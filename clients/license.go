@@ -0,0 +1,34 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+// License is a single license match found by detecting license text or an
+// SPDX header in a repo's files, independent of which forge reported it.
+type License struct {
+	// Key is the classifier's identifier for the matched license, e.g.
+	// "Apache-2.0". It is usually, but not always, a valid SPDXID.
+	Key string
+	// SPDXID is the SPDX license identifier for the match, e.g. "Apache-2.0".
+	SPDXID string
+	// Confidence is the classifier's match confidence, in [0,1].
+	Confidence float64
+	// StartLine and EndLine are the 1-indexed, inclusive line range the
+	// license text was matched at within File.
+	StartLine int
+	EndLine   int
+	// File is the path of the file the match was found in, relative to the
+	// repo root.
+	File string
+}
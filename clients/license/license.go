@@ -0,0 +1,195 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package license provides forge-independent license detection, so that
+// RepoClient implementations can report clients.License results without
+// relying on host-provided (and often missing or coarse) license metadata.
+package license
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	licenseclassifier "github.com/google/licenseclassifier/v2"
+	"github.com/google/licenseclassifier/v2/assets"
+
+	"github.com/ossf/scorecard/v4/clients"
+)
+
+// DefaultConfidenceThreshold is the minimum match confidence, in [0,1],
+// a license match must reach to be reported.
+const DefaultConfidenceThreshold = 0.9
+
+// reSPDXHeader matches an SPDX license identifier header comment, e.g.
+// "// SPDX-License-Identifier: Apache-2.0" or "# SPDX-License-Identifier:
+// MIT OR Apache-2.0". google/licenseclassifier has no SPDX-tag-aware
+// matching: a one-line header never contains enough license text to clear
+// its full-text confidence threshold, so source files are scanned for this
+// tag directly instead of being run through the classifier.
+var reSPDXHeader = regexp.MustCompile(`(?i)SPDX-License-Identifier:\s*([A-Za-z0-9.\-+() ]+)`)
+
+// licensePathGlobs are the file paths Detect scans in addition to top-level
+// source files. A file matching any of them is scanned; their order does not
+// affect the result.
+var licensePathGlobs = []string{
+	"LICENSE*",
+	"COPYING*",
+	"LICENCE*",
+	"licenses/*",
+}
+
+// Detector runs the google/licenseclassifier corpus over a repo's files to
+// identify its license(s).
+type Detector struct {
+	classifier *licenseclassifier.Classifier
+	threshold  float64
+}
+
+// NewDetector returns a Detector that only reports matches whose confidence
+// is at or above threshold. A threshold of 0 uses DefaultConfidenceThreshold.
+//
+// The classifier is built from licenseclassifier's bundled corpus via
+// assets.DefaultClassifier, which fixes its own internal match threshold;
+// Detector applies threshold on top of that as the reporting cutoff, so
+// threshold values below the classifier's internal floor have no effect.
+func NewDetector(threshold float64) (*Detector, error) {
+	if threshold <= 0 {
+		threshold = DefaultConfidenceThreshold
+	}
+	classifier, err := assets.DefaultClassifier()
+	if err != nil {
+		return nil, fmt.Errorf("assets.DefaultClassifier: %w", err)
+	}
+	return &Detector{classifier: classifier, threshold: threshold}, nil
+}
+
+// Detect scans the files reported by listFiles (and read with
+// getFileContent) for license text and SPDX headers, returning every match
+// found at or above the detector's confidence threshold.
+func (d *Detector) Detect(
+	listFiles func(predicate func(string) (bool, error)) ([]string, error),
+	getFileContent func(string) ([]byte, error),
+) ([]clients.License, error) {
+	candidates, err := listFiles(func(name string) (bool, error) {
+		return isLicenseTextCandidate(name) || isSourceFileCandidate(name), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listFiles: %w", err)
+	}
+
+	var licenses []clients.License
+	for _, name := range candidates {
+		content, err := getFileContent(name)
+		if err != nil {
+			return nil, fmt.Errorf("getFileContent %s: %w", name, err)
+		}
+
+		var matches []clients.License
+		if isLicenseTextCandidate(name) {
+			matches = d.classifyFile(name, content)
+		} else {
+			matches = spdxHeaderMatches(name, content)
+		}
+		licenses = append(licenses, matches...)
+	}
+	return licenses, nil
+}
+
+func (d *Detector) classifyFile(name string, content []byte) []clients.License {
+	result := d.classifier.Match(content)
+
+	var licenses []clients.License
+	for _, m := range result.Matches {
+		if m.Confidence < d.threshold {
+			continue
+		}
+		licenses = append(licenses, clients.License{
+			Key:        m.Name,
+			SPDXID:     m.Name,
+			Confidence: m.Confidence,
+			StartLine:  m.StartLine,
+			EndLine:    m.EndLine,
+			File:       name,
+		})
+	}
+	return licenses
+}
+
+// isLicenseTextCandidate reports whether name is a well-known license file
+// path, worth running the full-text classifier over.
+func isLicenseTextCandidate(name string) bool {
+	base := filepath.Base(name)
+	for _, glob := range licensePathGlobs {
+		if ok, _ := filepath.Match(glob, name); ok {
+			return true
+		}
+		// A glob with no directory component (e.g. "LICENSE*") also
+		// matches that file nested at any depth (e.g. "vendor/LICENSE"
+		// or "third_party/x/LICENSE"); one with a directory component (e.g.
+		// "licenses/*") already anchors the match above and must not be
+		// reduced to its base pattern, or "licenses/*" would become "*"
+		// and match every file in the tree.
+		if strings.Contains(glob, "/") {
+			continue
+		}
+		if ok, _ := filepath.Match(glob, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isSourceFileCandidate reports whether name is a top-level source file
+// worth scanning for an SPDX-License-Identifier header.
+func isSourceFileCandidate(name string) bool {
+	if strings.Contains(name, "/") {
+		return false
+	}
+	switch filepath.Ext(name) {
+	case ".go", ".c", ".cc", ".cpp", ".h", ".hpp", ".java", ".js", ".ts", ".py", ".rs":
+		return true
+	default:
+		return false
+	}
+}
+
+// spdxHeaderMatches scans content for SPDX-License-Identifier headers,
+// reporting one clients.License per line that carries one. Confidence is
+// reported as 1: this is an exact tag match, not a classifier score.
+func spdxHeaderMatches(name string, content []byte) []clients.License {
+	var licenses []clients.License
+	lineNum := 0
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		lineNum++
+		m := reSPDXHeader.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		spdxID := strings.TrimSpace(m[1])
+		licenses = append(licenses, clients.License{
+			Key:        spdxID,
+			SPDXID:     spdxID,
+			Confidence: 1,
+			StartLine:  lineNum,
+			EndLine:    lineNum,
+			File:       name,
+		})
+	}
+	return licenses
+}
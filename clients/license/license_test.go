@@ -0,0 +1,58 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package license
+
+import "testing"
+
+func Test_isLicenseTextCandidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"LICENSE", true},
+		{"LICENSE.txt", true},
+		{"vendor/LICENSE", true},
+		{"licenses/MIT.txt", true},
+		{"licenses/nested/MIT.txt", false},
+		{"main.go", false},
+	}
+	for _, tt := range tests {
+		if got := isLicenseTextCandidate(tt.name); got != tt.want {
+			t.Errorf("isLicenseTextCandidate(%q) = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func Test_spdxHeaderMatches(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("// SPDX-License-Identifier: Apache-2.0\npackage foo\n")
+	matches := spdxHeaderMatches("main.go", content)
+	if len(matches) != 1 {
+		t.Fatalf("expected 1 match, got %d: %+v", len(matches), matches)
+	}
+	if matches[0].SPDXID != "Apache-2.0" {
+		t.Errorf("expected SPDXID Apache-2.0, got %q", matches[0].SPDXID)
+	}
+	if matches[0].StartLine != 1 || matches[0].EndLine != 1 {
+		t.Errorf("expected line 1, got start=%d end=%d", matches[0].StartLine, matches[0].EndLine)
+	}
+
+	if got := spdxHeaderMatches("main.go", []byte("package foo\n")); got != nil {
+		t.Errorf("expected no match without an SPDX header, got %+v", got)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Security Scorecard Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clients
+
+import "context"
+
+// HeadSHA tells InitRepo to resolve the repo's current default-branch head,
+// rather than pinning to a specific commit.
+const HeadSHA = ""
+
+// Repo identifies a repository to a RepoClient's InitRepo, independent of
+// the forge it came from.
+type Repo interface {
+	URI() string
+}
+
+// RepoClient is the interface a forge-specific client (githubrepo, gitlabrepo,
+// ...) implements so checks can read a repo's state without depending on the
+// forge's own SDK.
+//
+// This only lists the members this series' checks call through the
+// interface type: file access, lifecycle, and the org-level/CI-config hooks
+// added here. The rest of a concrete client's surface (ListCommits,
+// ListIssues, Search, ...) predates this series and belongs to the forge
+// clients that implement it.
+type RepoClient interface {
+	URI() string
+	ListFiles(predicate func(string) (bool, error)) ([]string, error)
+	GetFileContent(filename string) ([]byte, error)
+	// GetCIConfig returns the repo's CI pipeline configuration, normalized
+	// across forges. Implementations that have none return (nil, nil).
+	GetCIConfig() (*CIConfig, error)
+	// OrgRepoClient returns a RepoClient scoped to the repo's org/group-level
+	// health-file repository, for checks that fall back to an org-wide
+	// policy when the repo itself has none. Implementations that can't
+	// resolve one return ErrUnsupportedFeature.
+	OrgRepoClient(ctx context.Context) (RepoClient, error)
+	Close() error
+}
+
+// ErrUnsupportedFeature is returned by a RepoClient method whose forge, or
+// the specific repo/instance it's talking to, can't support it.
+var ErrUnsupportedFeature = errUnsupportedFeature{}
+
+type errUnsupportedFeature struct{}
+
+func (errUnsupportedFeature) Error() string { return "unsupported feature" }